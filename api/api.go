@@ -0,0 +1,74 @@
+/*
+###########################################################################
+#
+#   Filename:           api.go
+#
+#   Author:             Siva Teja Areti
+#   Created:            June 6, 2017
+#
+#   Description:        inter-module event API
+#
+###########################################################################
+#
+#              Copyright (c) 2017 Nuage Networks
+#
+###########################################################################
+*/
+
+package api
+
+//DockerEventType identifies the kind of request sent on the docker channel
+type DockerEventType int
+
+//Docker event types served by the docker client module
+const (
+	DockerCheckNetworkListEvent DockerEventType = iota
+	DockerNetworkIDInspectEvent
+	DockerPoolIDNetworkOptsEvent
+	DockerContainerListEvent
+	DockerGetOptsAllNetworksEvent
+	DockerIsSwarmEnabled
+	DockerIsSwarmManager
+	DockerIsServiceIP
+	DockerFindUniqueNetworkEvent
+	DockerCreateAttachableNetwork
+)
+
+//VSDEventType identifies the kind of request sent on the vsd channel
+type VSDEventType int
+
+//VSD event types served by the vsd module
+const (
+	VSDUpdateContainerEvent VSDEventType = iota
+)
+
+//DockerEvent is a request sent to the docker client module
+type DockerEvent struct {
+	EventType            DockerEventType
+	DockerReqObject      interface{}
+	DockerRespObjectChan chan *DockerRespObject
+}
+
+//DockerRespObject is the response to a DockerEvent
+type DockerRespObject struct {
+	DockerData interface{}
+	Error      error
+}
+
+//VSDEvent is a request sent to the VSD module
+type VSDEvent struct {
+	EventType    VSDEventType
+	VSDReqObject interface{}
+}
+
+//NuageLibNetworkChannels holds the channels shared across plugin modules
+type NuageLibNetworkChannels struct {
+	Stop          chan bool
+	DockerChannel chan *DockerEvent
+	VSDChannel    chan *VSDEvent
+}
+
+//VSDChanRequest sends a request on the vsd channel
+func VSDChanRequest(vsdChannel chan *VSDEvent, eventType VSDEventType, reqObject interface{}) {
+	vsdChannel <- &VSDEvent{EventType: eventType, VSDReqObject: reqObject}
+}
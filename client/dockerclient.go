@@ -18,18 +18,27 @@
 package client
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/cli/cli/connhelper"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/api/types/versions"
 	dockerClient "github.com/docker/docker/client"
 	nuageApi "github.com/nuagenetworks/nuage-libnetwork/api"
 	nuageConfig "github.com/nuagenetworks/nuage-libnetwork/config"
 	"github.com/nuagenetworks/nuage-libnetwork/utils"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"io/ioutil"
 	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
@@ -37,17 +46,24 @@ import (
 	"time"
 )
 
+//defaultDockerAPIVersion is requested before version negotiation narrows it down
+const defaultDockerAPIVersion = "1.24"
+
 //NuageDockerClient structure holds docker client
 type NuageDockerClient struct {
 	socketFile         string
+	dockerEndpoint     string
+	dockerTLS          *nuageConfig.DockerTLSConfig
 	dclient            *dockerClient.Client
 	connectionRetry    chan bool
 	connectionActive   chan bool
 	stop               chan bool
+	stopCtx            context.Context
 	dockerChannel      chan *nuageApi.DockerEvent
 	vsdChannel         chan *nuageApi.VSDEvent
 	networkParamsTable *utils.HashMap
 	serviceIPCache     *utils.HashMap
+	serviceVIPIndex    *utils.HashMap
 	pluginVersion      string
 	sync.Mutex
 }
@@ -57,15 +73,24 @@ func NewNuageDockerClient(config *nuageConfig.NuageLibNetworkConfig, channels *n
 	var err error
 	nuagedocker := &NuageDockerClient{}
 	nuagedocker.stop = channels.Stop
+	stopCtx, stopCancel := context.WithCancel(context.Background())
+	nuagedocker.stopCtx = stopCtx
+	go func() {
+		<-nuagedocker.stop
+		stopCancel()
+	}()
 	nuagedocker.dockerChannel = channels.DockerChannel
 	nuagedocker.vsdChannel = channels.VSDChannel
 	nuagedocker.connectionRetry = make(chan bool)
 	nuagedocker.connectionActive = make(chan bool)
 	nuagedocker.networkParamsTable = utils.NewHashMap()
 	nuagedocker.serviceIPCache = utils.NewHashMap()
+	nuagedocker.serviceVIPIndex = utils.NewHashMap()
 	nuagedocker.pluginVersion = config.PluginVersion
 	nuagedocker.socketFile = config.DockerSocketFile
-	nuagedocker.dclient, err = connectToDockerDaemon(nuagedocker.socketFile)
+	nuagedocker.dockerEndpoint = config.DockerEndpoint
+	nuagedocker.dockerTLS = config.DockerTLS
+	nuagedocker.dclient, err = connectToDockerDaemon(nuagedocker.socketFile, nuagedocker.dockerEndpoint, nuagedocker.dockerTLS)
 	if err != nil {
 		log.Errorf("Connecting to docker client failed with error %v", err)
 		return nil, err
@@ -120,6 +145,16 @@ func (nuagedocker *NuageDockerClient) CheckNetworkList(nuageParams *nuageConfig.
 		}
 
 		if matchingNetworkOpts && overlappingSubnets {
+			//an attachable swarm-scope network and a local-scope network for
+			//the same opts/subnet are allowed to coexist regardless of which
+			//one already existed, so this has to check both sides' scope
+			//rather than assuming nuageParams is always the attachable one
+			attachablePairing := (nuageParams.Attachable && network.Scope == "local") ||
+				(!nuageParams.Attachable && network.Scope == "swarm" && network.Attachable)
+			if attachablePairing {
+				log.Debugf("allowing subnet reuse between attachable network %s and existing network %s", nuageParams.SubnetName, network.ID)
+				continue
+			}
 			return true, fmt.Errorf("Network options and subnet overlap with existing network")
 		}
 	}
@@ -127,22 +162,167 @@ func (nuagedocker *NuageDockerClient) CheckNetworkList(nuageParams *nuageConfig.
 	return false, nil
 }
 
+//FindUniqueNetwork resolves nuageParams to a single docker network that
+//matches it by name/opts, disambiguating between multiple matches the same
+//way findUniqueNetwork does for GetNetworkOptsFromPoolID
+func (nuagedocker *NuageDockerClient) FindUniqueNetwork(nuageParams *nuageConfig.NuageNetworkParams) (*nuageConfig.NuageNetworkParams, error) {
+	networkList, err := nuagedocker.dockerNetworkList()
+	if err != nil {
+		log.Errorf("Retrieving existing networks from docker failed with error: %v", err)
+		return nil, err
+	}
+
+	var candidates []types.NetworkResource
+	var candidateOpts []*nuageConfig.NuageNetworkParams
+	for _, network := range networkList {
+		existingNetworkOptions := nuageConfig.ParseNuageParams(network.IPAM.Options)
+		if !nuageConfig.IsSameNetworkOpts(existingNetworkOptions, nuageParams) {
+			continue
+		}
+		if len(network.IPAM.Config) > 0 {
+			existingNetworkOptions.SubnetCIDR = network.IPAM.Config[0].Subnet
+			existingNetworkOptions.Gateway = network.IPAM.Config[0].Gateway
+		}
+		candidates = append(candidates, network)
+		candidateOpts = append(candidateOpts, existingNetworkOptions)
+	}
+
+	selected, err := findUniqueNetwork(candidates, nuageParams.NetworkID)
+	if err != nil {
+		log.Errorf("Resolving unique network for %+v failed with error: %v", nuageParams, err)
+		return nil, err
+	}
+	for i := range candidates {
+		if candidates[i].ID == selected.ID {
+			return candidateOpts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("network options with matching opts not found")
+}
+
+//CreateAttachableNetwork provisions a swarm-scope network with
+//Attachable=true so that, in addition to swarm services, a standalone
+//container can join it via `docker run --network`, per moby/moby #25962
+func (nuagedocker *NuageDockerClient) CreateAttachableNetwork(nuageParams *nuageConfig.NuageNetworkParams) (string, error) {
+	var networkCreateResp types.NetworkCreateResponse
+	var err error
+
+	nuagedocker.executeDockerCommand(
+		func() error {
+			networkCreateResp, err = nuagedocker.dclient.NetworkCreate(context.Background(), nuageParams.SubnetName, types.NetworkCreate{
+				Driver:     nuageConfig.DockerNetworkType[nuagedocker.pluginVersion],
+				Attachable: true,
+				IPAM: &network.IPAM{
+					//the same keys ParseNuageParams reads back, so that
+					//FindUniqueNetwork below can match the new network by opts
+					Options: map[string]string{
+						"organization": nuageParams.Organization,
+						"domain":       nuageParams.Domain,
+						"zone":         nuageParams.Zone,
+						"subnetName":   nuageParams.SubnetName,
+						"user":         nuageParams.User,
+						"attachable":   "true",
+					},
+					Config: []network.IPAMConfig{
+						{
+							Subnet:  nuageParams.SubnetCIDR,
+							Gateway: nuageParams.Gateway,
+						},
+					},
+				},
+			})
+			return err
+		})
+	if err != nil {
+		log.Errorf("Creating attachable network %s failed with error: %v", nuageParams.SubnetName, err)
+		return "", err
+	}
+
+	//confirm the network resolves deterministically even if an older
+	//local-scope network shares the same opts/subnet - the exact ID match
+	//in findUniqueNetwork is what breaks that tie in our favor here
+	nuageParams.NetworkID = networkCreateResp.ID
+	if _, err := nuagedocker.FindUniqueNetwork(nuageParams); err != nil {
+		log.Errorf("Resolving newly created attachable network %s failed with error: %v", networkCreateResp.ID, err)
+		return "", err
+	}
+
+	return networkCreateResp.ID, nil
+}
+
+//ErrAmbiguousNetwork is returned when more than one network matches a
+//lookup by name/options and neither an exact ID match nor the swarm-over-
+//local scope preference can break the tie, mirroring the FindUniqueNetwork
+//helper added to moby/moby in PR #30242
+var ErrAmbiguousNetwork = fmt.Errorf("network options match more than one network")
+
+//findUniqueNetwork picks a single network out of candidates that all
+//matched a lookup by name/opts. It prefers an exact ID match against
+//preferredID (when given - e.g. CreateAttachableNetwork passes the ID it
+//just created to confirm it resolves over an older network with the same
+//opts), then a network in swarm scope over one in local scope, and returns
+//ErrAmbiguousNetwork when neither rule breaks the tie.
+func findUniqueNetwork(candidates []types.NetworkResource, preferredID string) (*types.NetworkResource, error) {
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("network options with matching poolID not found")
+	}
+	if len(candidates) == 1 {
+		return &candidates[0], nil
+	}
+
+	if preferredID != "" {
+		for i := range candidates {
+			if candidates[i].ID == preferredID {
+				return &candidates[i], nil
+			}
+		}
+	}
+
+	var swarmScoped []int
+	for i := range candidates {
+		if candidates[i].Scope == "swarm" {
+			swarmScoped = append(swarmScoped, i)
+		}
+	}
+	if len(swarmScoped) == 1 {
+		return &candidates[swarmScoped[0]], nil
+	}
+
+	return nil, ErrAmbiguousNetwork
+}
+
 //GetNetworkOptsFromPoolID fetches network options for a given docker network
 func (nuagedocker *NuageDockerClient) GetNetworkOptsFromPoolID(poolID string) (*nuageConfig.NuageNetworkParams, error) {
-	networkOpts := &nuageConfig.NuageNetworkParams{}
 	networkList, err := nuagedocker.dockerNetworkList()
 	if err != nil {
 		log.Errorf("Retrieving existing networks from docker failed with error: %v", err)
 		return nil, err
 	}
+
+	var candidates []types.NetworkResource
+	var candidateOpts []*nuageConfig.NuageNetworkParams
 	for _, network := range networkList {
 		if network.IPAM.Options == nil || len(network.IPAM.Config) == 0 {
 			continue
 		}
-		networkOpts = nuageConfig.ParseNuageParams(network.IPAM.Options)
+		networkOpts := nuageConfig.ParseNuageParams(network.IPAM.Options)
 		networkOpts.SubnetCIDR = network.IPAM.Config[0].Subnet
 		if poolID == nuageConfig.MD5Hash(networkOpts) {
-			return networkOpts, nil
+			candidates = append(candidates, network)
+			candidateOpts = append(candidateOpts, networkOpts)
+		}
+	}
+
+	//poolID is derived from a network's opts, not its ID, so there is no ID
+	//hint to pass here; ties can only be broken by swarm/local scope
+	selected, err := findUniqueNetwork(candidates, "")
+	if err != nil {
+		log.Errorf("Resolving network for poolID %s failed with error: %v", poolID, err)
+		return nil, err
+	}
+	for i := range candidates {
+		if candidates[i].ID == selected.ID {
+			return candidateOpts[i], nil
 		}
 	}
 	return nil, fmt.Errorf("network options with matching poolID not found")
@@ -194,6 +374,19 @@ func (nuagedocker *NuageDockerClient) GetContainerInspect(uuid string) (types.Co
 	return containerInspect, nil
 }
 
+//isNuageNetworkEvent reports whether msg is an event on a network of this
+//plugin's driver type that is also present in networkParamsTable, i.e. a
+//network found by buildNetworkInfoCache at startup or inspected later
+//through GetNetworkOptsFromNetworkID. Events on networks missing from that
+//table are not passed on, whoever created the network.
+func (nuagedocker *NuageDockerClient) isNuageNetworkEvent(msg events.Message) bool {
+	if msg.Actor.Attributes["type"] != nuageConfig.DockerNetworkType[nuagedocker.pluginVersion] {
+		return false
+	}
+	_, exists := nuagedocker.networkParamsTable.Read(msg.Actor.ID)
+	return exists
+}
+
 //GetNetworkConnectEvents listens for event when a container is connected to "nuage" network
 func (nuagedocker *NuageDockerClient) GetNetworkConnectEvents() {
 	filterArgs := filters.NewArgs()
@@ -207,7 +400,7 @@ func (nuagedocker *NuageDockerClient) GetNetworkConnectEvents() {
 	for {
 		select {
 		case eventMsg := <-eventsChanRO:
-			if eventMsg.Actor.Attributes["type"] == nuageConfig.DockerNetworkType[nuagedocker.pluginVersion] {
+			if nuagedocker.isNuageNetworkEvent(eventMsg) {
 				log.Debugf("got docker event %+v", eventMsg)
 				go nuagedocker.processEvent(eventMsg)
 			}
@@ -220,6 +413,58 @@ func (nuagedocker *NuageDockerClient) GetNetworkConnectEvents() {
 	}
 }
 
+//GetServiceEvents listens for swarm service create/update/remove events so
+//the service IP cache can be updated incrementally instead of polling
+func (nuagedocker *NuageDockerClient) GetServiceEvents() {
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("type", "service")
+	filterArgs.Add("event", "create")
+	filterArgs.Add("event", "update")
+	filterArgs.Add("event", "remove")
+	options := types.EventsOptions{
+		Filters: filterArgs,
+	}
+
+	eventsChanRO, errChan := nuagedocker.dclient.Events(context.Background(), options)
+	for {
+		select {
+		case eventMsg := <-eventsChanRO:
+			log.Debugf("got docker service event %+v", eventMsg)
+			go nuagedocker.processServiceEvent(eventMsg)
+		case <-errChan:
+			nuagedocker.connectionRetry <- true
+			<-nuagedocker.connectionActive
+			go nuagedocker.GetServiceEvents()
+			return
+		}
+	}
+}
+
+//GetNetworkDestroyEvents listens for network destroy events so the
+//corresponding entry can be dropped from the service IP cache right away
+func (nuagedocker *NuageDockerClient) GetNetworkDestroyEvents() {
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("type", "network")
+	filterArgs.Add("event", "destroy")
+	options := types.EventsOptions{
+		Filters: filterArgs,
+	}
+
+	eventsChanRO, errChan := nuagedocker.dclient.Events(context.Background(), options)
+	for {
+		select {
+		case eventMsg := <-eventsChanRO:
+			log.Debugf("got docker network destroy event %+v", eventMsg)
+			go nuagedocker.processNetworkDestroyEvent(eventMsg)
+		case <-errChan:
+			nuagedocker.connectionRetry <- true
+			<-nuagedocker.connectionActive
+			go nuagedocker.GetNetworkDestroyEvents()
+			return
+		}
+	}
+}
+
 //isSwarmEnabled checks if the docker swarm is enabled on current node
 func (nuagedocker *NuageDockerClient) isSwarmEnabled() (bool, error) {
 	info, err := nuagedocker.dclient.Info(context.Background())
@@ -246,59 +491,165 @@ func (nuagedocker *NuageDockerClient) isSwarmManager() (bool, error) {
 	return info.Swarm.ControlAvailable, nil
 }
 
+//serviceIPCacheReconcileInterval is the period of the full service IP cache
+//rebuild that runs as a safety net behind the event-driven updates
+const serviceIPCacheReconcileInterval = 5 * time.Minute
+
+//buildServiceIPCache does a full rebuild of the service IP cache from the
+//current service list. It is only needed as a safety net for events missed
+//while the daemon connection was down; day to day updates come from
+//processServiceEvent/processNetworkDestroyEvent.
 func (nuagedocker *NuageDockerClient) buildServiceIPCache() {
 	manager, err := nuagedocker.isSwarmManager()
 	if manager && err == nil {
-		//need another level of mutex as we are accessing map of map
-		nuagedocker.Lock()
-		defer nuagedocker.Unlock()
-		// clear the cache
-		for _, id := range nuagedocker.serviceIPCache.GetKeys() {
-			nuagedocker.serviceIPCache.Write(id, nil)
-		}
-
 		services, err := nuagedocker.dclient.ServiceList(context.Background(), types.ServiceListOptions{})
 		if err != nil {
 			log.Errorf("Fetching list of services from docker daemon failed with error: %v", err)
-			return
+		} else {
+			seenServices := make(map[string]bool)
+			for _, service := range services {
+				seenServices[service.ID] = true
+				nuagedocker.updateServiceIPCache(service.ID, service.Endpoint.VirtualIPs)
+			}
+			for _, serviceID := range nuagedocker.serviceVIPIndex.GetKeys() {
+				if !seenServices[serviceID] {
+					nuagedocker.removeServiceFromCache(serviceID)
+				}
+			}
 		}
+	}
+	time.AfterFunc(serviceIPCacheReconcileInterval, func() { nuagedocker.buildServiceIPCache() })
+}
 
-		for _, service := range services {
-			for _, vip := range service.Endpoint.VirtualIPs {
-				if vip.Addr == "" {
-					continue
-				}
-				var serviceIPMap map[string]bool
-				serviceIPMapInterface, exists := nuagedocker.serviceIPCache.Read(vip.NetworkID)
-				if exists {
-					serviceIPMap = serviceIPMapInterface.(map[string]bool)
-				} else {
-					serviceIPMap = make(map[string]bool)
-				}
-				serviceIPMap[vip.Addr] = true
-				var networkOpts *nuageConfig.NuageNetworkParams
-				networkOptsIntf, inCache := nuagedocker.networkParamsTable.Read(vip.NetworkID)
-				if !inCache {
-					networkOpts, err = nuagedocker.GetNetworkOptsFromNetworkID(vip.NetworkID)
-					if err != nil {
-						log.Errorf("Fetching network opts from network ID failed with error: %v", err)
-						return
-					}
-				} else {
-					networkOpts = networkOptsIntf.(*nuageConfig.NuageNetworkParams)
-				}
-				nuagedocker.serviceIPCache.Write(nuageConfig.MD5Hash(networkOpts), serviceIPMap)
+//processServiceEvent keeps the service IP cache in sync with a single
+//service's create/update/remove event
+func (nuagedocker *NuageDockerClient) processServiceEvent(msg events.Message) {
+	serviceID := msg.Actor.ID
+	if msg.Action == "remove" {
+		nuagedocker.removeServiceFromCache(serviceID)
+		return
+	}
+
+	var serviceInspect swarm.Service
+	var err error
+	nuagedocker.executeDockerCommand(
+		func() error {
+			serviceInspect, _, err = nuagedocker.dclient.ServiceInspectWithRaw(context.Background(), serviceID, types.ServiceInspectOptions{})
+			return err
+		})
+	if err != nil {
+		log.Errorf("ServiceInspectWithRaw for service %s failed with error: %v", serviceID, err)
+		return
+	}
+
+	nuagedocker.updateServiceIPCache(serviceID, serviceInspect.Endpoint.VirtualIPs)
+}
+
+//processNetworkDestroyEvent drops the destroyed network's entry from the
+//service IP cache
+func (nuagedocker *NuageDockerClient) processNetworkDestroyEvent(msg events.Message) {
+	networkOptsIntf, exists := nuagedocker.networkParamsTable.Read(msg.Actor.ID)
+	if !exists {
+		return
+	}
+	networkOpts := networkOptsIntf.(*nuageConfig.NuageNetworkParams)
+
+	nuagedocker.Lock()
+	defer nuagedocker.Unlock()
+	nuagedocker.serviceIPCache.Write(nuageConfig.MD5Hash(networkOpts), map[string]bool{})
+}
+
+//updateServiceIPCache diffs a service's current virtual IPs against the
+//ones recorded for it in serviceVIPIndex and applies only the delta to
+//serviceIPCache, keyed by the same MD5Hash(networkOpts) poolID used
+//elsewhere
+func (nuagedocker *NuageDockerClient) updateServiceIPCache(serviceID string, virtualIPs []swarm.EndpointVirtualIP) {
+	newVIPs := make(map[string]string)
+	for _, vip := range virtualIPs {
+		if vip.Addr == "" {
+			continue
+		}
+		var networkOpts *nuageConfig.NuageNetworkParams
+		networkOptsIntf, inCache := nuagedocker.networkParamsTable.Read(vip.NetworkID)
+		if inCache {
+			networkOpts = networkOptsIntf.(*nuageConfig.NuageNetworkParams)
+		} else {
+			var err error
+			networkOpts, err = nuagedocker.GetNetworkOptsFromNetworkID(vip.NetworkID)
+			if err != nil {
+				log.Errorf("Fetching network opts from network ID failed with error: %v", err)
+				continue
 			}
 		}
+		newVIPs[nuageConfig.MD5Hash(networkOpts)] = vip.Addr
+	}
+
+	nuagedocker.Lock()
+	defer nuagedocker.Unlock()
+
+	var oldVIPs map[string]string
+	if oldVIPsIntf, exists := nuagedocker.serviceVIPIndex.Read(serviceID); exists && oldVIPsIntf != nil {
+		oldVIPs = oldVIPsIntf.(map[string]string)
+	}
+
+	for poolID, ip := range oldVIPs {
+		if newVIPs[poolID] != ip {
+			nuagedocker.removeServiceIPLocked(poolID, ip)
+		}
+	}
+	for poolID, ip := range newVIPs {
+		if oldVIPs[poolID] != ip {
+			nuagedocker.addServiceIPLocked(poolID, ip)
+		}
 	}
-	time.AfterFunc(30*time.Second, func() { nuagedocker.buildServiceIPCache() })
+
+	nuagedocker.serviceVIPIndex.Write(serviceID, newVIPs)
+}
+
+//removeServiceFromCache clears every IP that was last recorded for
+//serviceID out of serviceIPCache, e.g. when the service itself is removed
+func (nuagedocker *NuageDockerClient) removeServiceFromCache(serviceID string) {
+	nuagedocker.Lock()
+	defer nuagedocker.Unlock()
+
+	oldVIPsIntf, exists := nuagedocker.serviceVIPIndex.Read(serviceID)
+	if !exists || oldVIPsIntf == nil {
+		return
+	}
+	for poolID, ip := range oldVIPsIntf.(map[string]string) {
+		nuagedocker.removeServiceIPLocked(poolID, ip)
+	}
+	nuagedocker.serviceVIPIndex.Write(serviceID, nil)
+}
+
+//addServiceIPLocked and removeServiceIPLocked assume nuagedocker's mutex is
+//already held
+func (nuagedocker *NuageDockerClient) addServiceIPLocked(poolID string, ip string) {
+	var serviceIPMap map[string]bool
+	if mapIntf, exists := nuagedocker.serviceIPCache.Read(poolID); exists && mapIntf != nil {
+		serviceIPMap = mapIntf.(map[string]bool)
+	} else {
+		serviceIPMap = make(map[string]bool)
+	}
+	serviceIPMap[ip] = true
+	nuagedocker.serviceIPCache.Write(poolID, serviceIPMap)
+}
+
+func (nuagedocker *NuageDockerClient) removeServiceIPLocked(poolID string, ip string) {
+	mapIntf, exists := nuagedocker.serviceIPCache.Read(poolID)
+	if !exists || mapIntf == nil {
+		return
+	}
+	serviceIPMap := mapIntf.(map[string]bool)
+	delete(serviceIPMap, ip)
+	nuagedocker.serviceIPCache.Write(poolID, serviceIPMap)
 }
 
 func (nuagedocker *NuageDockerClient) isServiceIP(vsdReq *nuageConfig.NuageEventMetadata) bool {
 	nuagedocker.Lock()
 	defer nuagedocker.Unlock()
 	serviceIPMapIntf, exists := nuagedocker.serviceIPCache.Read(nuageConfig.MD5Hash(vsdReq.NetworkParams))
-	if !exists {
+	if !exists || serviceIPMapIntf == nil {
 		return false
 	}
 	serviceIPMap := serviceIPMapIntf.(map[string]bool)
@@ -426,6 +777,8 @@ func (nuagedocker *NuageDockerClient) Start() {
 	nuagedocker.buildServiceIPCache()
 
 	go nuagedocker.GetNetworkConnectEvents()
+	go nuagedocker.GetServiceEvents()
+	go nuagedocker.GetNetworkDestroyEvents()
 
 	for {
 		select {
@@ -433,7 +786,7 @@ func (nuagedocker *NuageDockerClient) Start() {
 			go nuagedocker.handleDockerEvent(dockerEvent)
 		case <-nuagedocker.connectionRetry:
 			nuagedocker.handleConnectionRetry()
-		case <-nuagedocker.stop:
+		case <-nuagedocker.stopCtx.Done():
 			return
 		}
 	}
@@ -454,6 +807,14 @@ func (nuagedocker *NuageDockerClient) handleDockerEvent(event *nuageApi.DockerEv
 		networkInfo, err := nuagedocker.GetNetworkOptsFromPoolID(event.DockerReqObject.(string))
 		event.DockerRespObjectChan <- &nuageApi.DockerRespObject{DockerData: networkInfo, Error: err}
 
+	case nuageApi.DockerFindUniqueNetworkEvent:
+		networkInfo, err := nuagedocker.FindUniqueNetwork(event.DockerReqObject.(*nuageConfig.NuageNetworkParams))
+		event.DockerRespObjectChan <- &nuageApi.DockerRespObject{DockerData: networkInfo, Error: err}
+
+	case nuageApi.DockerCreateAttachableNetwork:
+		networkID, err := nuagedocker.CreateAttachableNetwork(event.DockerReqObject.(*nuageConfig.NuageNetworkParams))
+		event.DockerRespObjectChan <- &nuageApi.DockerRespObject{DockerData: networkID, Error: err}
+
 	case nuageApi.DockerContainerListEvent:
 		containerList, err := nuagedocker.GetRunningContainerList()
 		event.DockerRespObjectChan <- &nuageApi.DockerRespObject{DockerData: containerList, Error: err}
@@ -480,51 +841,160 @@ func (nuagedocker *NuageDockerClient) handleDockerEvent(event *nuageApi.DockerEv
 	log.Debugf("Served docker event %+v", event)
 }
 
+//newReconnectBackOff returns the retry policy used while the docker daemon is unreachable
+func newReconnectBackOff() *backoff.ExponentialBackOff {
+	exponentialBackOff := backoff.NewExponentialBackOff()
+	exponentialBackOff.InitialInterval = 500 * time.Millisecond
+	exponentialBackOff.MaxInterval = 30 * time.Second
+	exponentialBackOff.MaxElapsedTime = 0
+	return exponentialBackOff
+}
+
 func (nuagedocker *NuageDockerClient) handleConnectionRetry() {
 	if _, err := nuagedocker.dclient.Ping(context.Background()); err != nil {
 		log.Errorf("Ping to docker host failed with error = %v. trying to reconnect", err)
-		log.Errorf("will try to reconnect in every 3 seconds")
-		var err error
-		for {
-			nuagedocker.dclient, err = connectToDockerDaemon(nuagedocker.socketFile)
-			_, err = nuagedocker.dclient.Ping(context.Background())
-			if err != nil {
-				time.Sleep(3 * time.Second)
-			} else {
-				log.Infof("docker connection is now active")
-				nuagedocker.connectionActive <- true
-				break
+		operation := func() error {
+			var connErr error
+			nuagedocker.dclient, connErr = connectToDockerDaemon(nuagedocker.socketFile, nuagedocker.dockerEndpoint, nuagedocker.dockerTLS)
+			if connErr != nil {
+				return connErr
 			}
+			_, connErr = nuagedocker.dclient.Ping(context.Background())
+			return connErr
+		}
+		notify := func(err error, wait time.Duration) {
+			log.Errorf("reconnect attempt to docker host failed with error = %v. retrying in %v", err, wait)
+		}
+		if err := backoff.RetryNotify(operation, backoff.WithContext(newReconnectBackOff(), nuagedocker.stopCtx), notify); err != nil {
+			log.Errorf("giving up reconnecting to docker host: %v", err)
+			return
 		}
+		log.Infof("docker connection is now active")
+		nuagedocker.connectionActive <- true
 	} else {
 		nuagedocker.connectionActive <- true
 	}
 }
 
-func connectToDockerDaemon(socketFile string) (*dockerClient.Client, error) {
-	err := os.Setenv("DOCKER_HOST", socketFile)
+//connectToDockerDaemon builds a docker API client for endpoint, falling back to socketFile when endpoint is empty; for tcp/unix hosts it also sets DOCKER_HOST to that host
+func connectToDockerDaemon(socketFile string, endpoint string, tlsConfig *nuageConfig.DockerTLSConfig) (*dockerClient.Client, error) {
+	host := endpoint
+	if host == "" {
+		host = socketFile
+	}
+
+	clientOpts := []dockerClient.Opt{dockerClient.WithHost(host)}
+
+	parsedURL, err := url.Parse(host)
 	if err != nil {
-		log.Errorf("Setting DOCKER_HOST failed with error: %v", err)
+		log.Errorf("Parsing docker endpoint %s failed with error: %v", host, err)
 		return nil, err
 	}
-	client, err := dockerClient.NewEnvClient()
+
+	switch parsedURL.Scheme {
+	case "", "tcp", "unix":
+		httpClient, err := buildDockerHTTPClient(parsedURL, tlsConfig)
+		if err != nil {
+			log.Errorf("Building docker HTTP client for %s failed with error: %v", host, err)
+			return nil, err
+		}
+		if httpClient != nil {
+			clientOpts = append(clientOpts, dockerClient.WithHTTPClient(httpClient))
+		}
+		if err := os.Setenv("DOCKER_HOST", host); err != nil {
+			log.Errorf("Setting DOCKER_HOST failed with error: %v", err)
+			return nil, err
+		}
+	default:
+		helper, err := connhelper.GetConnectionHelper(host)
+		if err != nil {
+			log.Errorf("Getting connection helper for %s failed with error: %v", host, err)
+			return nil, err
+		}
+		httpClient := &http.Client{
+			Transport: &http.Transport{DialContext: helper.Dialer},
+		}
+		clientOpts = []dockerClient.Opt{
+			dockerClient.WithHTTPClient(httpClient),
+			dockerClient.WithHost(helper.Host),
+			dockerClient.WithDialContext(helper.Dialer),
+		}
+	}
+
+	clientOpts = append(clientOpts, dockerClient.WithVersion(defaultDockerAPIVersion))
+
+	client, err := dockerClient.NewClientWithOpts(clientOpts...)
 	if err != nil {
 		log.Errorf("Connecting to docker client failed with error %v", err)
 		return nil, err
 	}
+
+	//negotiate down to the daemon's actual API version. WithVersion is a
+	//manual override that NegotiateAPIVersionPing leaves alone, so the
+	//client is rebuilt with the daemon's version instead
+	if ping, err := client.Ping(context.Background()); err == nil && ping.APIVersion != "" {
+		if versions.LessThan(ping.APIVersion, client.ClientVersion()) {
+			client, err = dockerClient.NewClientWithOpts(append(clientOpts, dockerClient.WithVersion(ping.APIVersion))...)
+			if err != nil {
+				log.Errorf("Connecting to docker client with API version %s failed with error %v", ping.APIVersion, err)
+				return nil, err
+			}
+		}
+	}
+
 	return client, nil
 }
 
+//buildDockerHTTPClient returns a TLS-enabled *http.Client when tlsConfig carries CA/cert/key material, or nil otherwise
+func buildDockerHTTPClient(parsedURL *url.URL, tlsConfig *nuageConfig.DockerTLSConfig) (*http.Client, error) {
+	if tlsConfig == nil || (tlsConfig.CAFile == "" && tlsConfig.CertFile == "" && tlsConfig.KeyFile == "") {
+		return nil, nil
+	}
+
+	tlsClientConfig := &tls.Config{InsecureSkipVerify: tlsConfig.InsecureSkipVerify}
+
+	if tlsConfig.CAFile != "" {
+		caCert, err := ioutil.ReadFile(tlsConfig.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("unable to parse CA certificate %s", tlsConfig.CAFile)
+		}
+		tlsClientConfig.RootCAs = caCertPool
+	}
+
+	if tlsConfig.CertFile != "" && tlsConfig.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := &http.Transport{TLSClientConfig: tlsClientConfig}
+	if parsedURL.Scheme == "unix" {
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", parsedURL.Path)
+		}
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+//executeDockerCommand runs dockerCommand, reconnecting and retrying in a loop (not by recursion) until it succeeds or fails with an error other than a daemon connection error; the number of retries is not limited
 func (nuagedocker *NuageDockerClient) executeDockerCommand(dockerCommand func() error) {
-	err := dockerCommand()
-	if err != nil && isDockerConnectionError(err.Error()) {
+	for {
+		err := dockerCommand()
+		if err == nil || !isDockerConnectionError(err.Error()) {
+			return
+		}
 		log.Errorf(err.Error())
 		nuagedocker.connectionRetry <- true
 		<-nuagedocker.connectionActive
-		nuagedocker.executeDockerCommand(dockerCommand)
-		return
 	}
-	return
 }
 
 func isDockerConnectionError(errMsg string) bool {
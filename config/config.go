@@ -0,0 +1,100 @@
+/*
+###########################################################################
+#
+#   Filename:           config.go
+#
+#   Author:             Siva Teja Areti
+#   Created:            June 6, 2017
+#
+#   Description:        libnetwork plugin configuration
+#
+###########################################################################
+#
+#              Copyright (c) 2017 Nuage Networks
+#
+###########################################################################
+*/
+
+package config
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+)
+
+//DockerNetworkType maps a plugin version to the docker network driver name it registers
+var DockerNetworkType = map[string]string{
+	"v1": "nuage",
+	"v2": "nuage",
+}
+
+//DockerTLSConfig holds the TLS material used to secure a connection to a remote docker endpoint
+type DockerTLSConfig struct {
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	InsecureSkipVerify bool
+}
+
+//NuageLibNetworkConfig holds the plugin's runtime configuration
+type NuageLibNetworkConfig struct {
+	PluginVersion    string
+	DockerSocketFile string
+	DockerEndpoint   string
+	DockerTLS        *DockerTLSConfig
+}
+
+//NuageNetworkParams holds the nuage network parameters parsed from a docker network's IPAM options
+type NuageNetworkParams struct {
+	Organization string
+	Domain       string
+	Zone         string
+	SubnetName   string
+	User         string
+	SubnetCIDR   string
+	Gateway      string
+	Attachable   bool
+	//NetworkID is an optional hint set by callers that already know which
+	//docker network they mean, e.g. to break a tie in FindUniqueNetwork.
+	//It is excluded from MD5Hash since it isn't part of a network's identity.
+	NetworkID string `json:"-"`
+}
+
+//NuageEventMetadata carries the data needed to notify VSD of a container network event
+type NuageEventMetadata struct {
+	Name            string
+	UUID            string
+	PolicyGroup     string
+	OrchestrationID string
+	IPAddress       string
+	NetworkParams   *NuageNetworkParams
+}
+
+//ParseNuageParams builds a NuageNetworkParams out of a docker network's IPAM options
+func ParseNuageParams(options map[string]string) *NuageNetworkParams {
+	return &NuageNetworkParams{
+		Organization: options["organization"],
+		Domain:       options["domain"],
+		Zone:         options["zone"],
+		SubnetName:   options["subnetName"],
+		User:         options["user"],
+		Attachable:   options["attachable"] == "true",
+	}
+}
+
+//IsSameNetworkOpts reports whether two NuageNetworkParams refer to the same nuage network
+func IsSameNetworkOpts(a *NuageNetworkParams, b *NuageNetworkParams) bool {
+	return a.Organization == b.Organization &&
+		a.Domain == b.Domain &&
+		a.Zone == b.Zone &&
+		a.SubnetName == b.SubnetName &&
+		a.User == b.User
+}
+
+//MD5Hash returns a stable pool ID for a NuageNetworkParams
+func MD5Hash(params *NuageNetworkParams) string {
+	data, _ := json.Marshal(params)
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,57 @@
+/*
+###########################################################################
+#
+#   Filename:           hashmap.go
+#
+#   Author:             Siva Teja Areti
+#   Created:            June 6, 2017
+#
+#   Description:        thread safe map used across the plugin's caches
+#
+###########################################################################
+#
+#              Copyright (c) 2017 Nuage Networks
+#
+###########################################################################
+*/
+
+package utils
+
+import "sync"
+
+//HashMap is a thread safe string-keyed map of arbitrary values
+type HashMap struct {
+	sync.RWMutex
+	entries map[string]interface{}
+}
+
+//NewHashMap creates an empty HashMap
+func NewHashMap() *HashMap {
+	return &HashMap{entries: make(map[string]interface{})}
+}
+
+//Read returns the value stored for key and whether it was present
+func (h *HashMap) Read(key string) (interface{}, bool) {
+	h.RLock()
+	defer h.RUnlock()
+	value, exists := h.entries[key]
+	return value, exists
+}
+
+//Write stores value under key
+func (h *HashMap) Write(key string, value interface{}) {
+	h.Lock()
+	defer h.Unlock()
+	h.entries[key] = value
+}
+
+//GetKeys returns a snapshot of the keys currently in the map
+func (h *HashMap) GetKeys() []string {
+	h.RLock()
+	defer h.RUnlock()
+	keys := make([]string, 0, len(h.entries))
+	for key := range h.entries {
+		keys = append(keys, key)
+	}
+	return keys
+}